@@ -7,7 +7,7 @@ You can configure it by passing an option struct to cors.New:
     c := cors.New(cors.Options{
         AllowedOrigins: []string{"foo.com"},
         AllowedMethods: []string{"GET", "POST", "DELETE"},
-        AllowCredentials: true,
+        AllowCredentials: cors.Bool(true),
     })
 
 Then insert the handler in the chain:
@@ -21,11 +21,20 @@ The resulting handler is a standard net/http handler.
 package cors
 
 import (
+	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 )
 
+// Logger is a minimal logging interface, satisfied by *log.Logger, used to
+// trace why a request was rejected when Options.Debug is set.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
 // Options is a configuration container to setup the CORS middleware.
 type Options struct {
 	// AllowedOrigins is a list of origins a cross-domain request can be executed from.
@@ -42,16 +51,87 @@ type Options struct {
 	// API specification
 	ExposedHeaders []string
 	// AllowCredentials indicates whether the request can include user credentials like
-	// cookies, HTTP authentication or client side SSL certificates.
-	AllowCredentials bool
+	// cookies, HTTP authentication or client side SSL certificates. A pointer so that
+	// With/MergeOptions can distinguish "not set, inherit the base" from "explicitly
+	// false", letting a route turn a base-enabled policy back off. Use cors.Bool.
+	AllowCredentials *bool
 	// MaxAge indicates how long (in seconds) the results of a preflight request
-	// can be cached
-	MaxAge int
+	// can be cached. A pointer for the same reason as AllowCredentials. Use cors.Int.
+	MaxAge *int
+	// AllowOriginFunc is a custom function to validate the origin, called with the
+	// request's Origin header. If set, it is consulted for every origin that doesn't
+	// match AllowedOrigins, which is useful when the set of allowed origins can't be
+	// enumerated statically (e.g. it's backed by a database of tenants). When it
+	// is set and AllowedOrigins is empty, the ["*"] default is not applied.
+	AllowOriginFunc func(origin string) bool
+	// OptionsPassthrough instructs the middleware to let the downstream handler
+	// run after every preflight, allowed or not, for services that use OPTIONS
+	// for their own semantics. Default is false: the middleware terminates the response
+	// itself, as the spec requires. A pointer for the same reason as
+	// AllowCredentials. Use cors.Bool.
+	OptionsPassthrough *bool
+	// OptionsSuccessStatus is the status code sent for a preflight request.
+	// It is sent whether or not the preflight is allowed; a rejected preflight
+	// differs only in lacking the CORS response headers. Default is 204. Some legacy XHR clients choke on 204, in which
+	// case 200 should be used instead.
+	OptionsSuccessStatus int
+	// Debug turns on logging of why a preflight request was rejected (origin
+	// not allowed, method not allowed, header not allowed). Logger is used if
+	// set, otherwise a *log.Logger writing to stdout is created. A pointer for
+	// the same reason as AllowCredentials. Use cors.Bool.
+	Debug *bool
+	// Logger receives the debug trace described above. Only consulted when
+	// Debug is true.
+	Logger Logger
+	// AllowPrivateNetwork controls the Private Network Access response. When
+	// a preflight carries Access-Control-Request-Private-Network: true,
+	// Access-Control-Allow-Private-Network: true is returned if this is set,
+	// otherwise the preflight is rejected. Required for pages served from a
+	// public origin to reach devices/services on the user's LAN under
+	// current Chromium. A pointer for the same reason as AllowCredentials.
+	// Use cors.Bool.
+	AllowPrivateNetwork *bool
+	// TimingAllowOrigins is a list of origins for which the
+	// Timing-Allow-Origin response header is set on actual requests, letting
+	// browsers expose Resource Timing detail to those origins. Unlike
+	// AllowedOrigins, this is independent of whether the request is itself
+	// CORS-allowed. The special "*" value allows all origins.
+	TimingAllowOrigins []string
 }
 
 type Cors struct {
 	// The CORS Options
 	options Options
+	// base holds the options as passed to New, for With/MergeOptions
+	base Options
+	// originSet holds the non-wildcard AllowedOrigins for O(1) lookup
+	originSet map[string]struct{}
+	// allowedOriginsAll is true when "*" is in AllowedOrigins
+	allowedOriginsAll bool
+	// wildcardOrigins holds the compiled matchers for wildcard AllowedOrigins
+	wildcardOrigins []wildcard
+	// methodSet holds the normalized AllowedMethods for O(1) lookup
+	methodSet map[string]struct{}
+	// headerSet holds the normalized AllowedHeaders for O(1) lookup
+	headerSet map[string]struct{}
+	// allowedHeadersAll is true when "*" is in AllowedHeaders
+	allowedHeadersAll bool
+	// allowMethodsStr is the precomputed Access-Control-Allow-Methods value
+	allowMethodsStr string
+	// allowedHeadersStr is the precomputed Access-Control-Allow-Headers value
+	allowedHeadersStr string
+	// exposedHeadersStr is the precomputed Access-Control-Expose-Headers value
+	exposedHeadersStr string
+	// maxAgeStr is the precomputed Access-Control-Max-Age value
+	maxAgeStr string
+	// optionsSuccessStatus is the status code written for a successful preflight
+	optionsSuccessStatus int
+	// logger receives debug traces when Options.Debug is set
+	logger Logger
+	// timingAllowOriginSet holds the normalized TimingAllowOrigins for O(1) lookup
+	timingAllowOriginSet map[string]struct{}
+	// timingAllowOriginsAll is true when "*" is in TimingAllowOrigins
+	timingAllowOriginsAll bool
 }
 
 // New creates a new Cors handler with the provided options.
@@ -64,21 +144,106 @@ func New(options Options) *Cors {
 		AllowedMethods: convert(options.AllowedMethods, strings.ToUpper),
 		// Origin is always appended as some browsers will always request
 		// for this header at preflight
-		AllowedHeaders:   convert(append(options.AllowedHeaders, "Origin"), toHeader),
-		ExposedHeaders:   convert(options.ExposedHeaders, toHeader),
-		AllowCredentials: options.AllowCredentials,
-		MaxAge:           options.MaxAge,
+		AllowedHeaders:      convert(append(options.AllowedHeaders, "Origin"), toHeader),
+		ExposedHeaders:      convert(options.ExposedHeaders, toHeader),
+		AllowCredentials:    options.AllowCredentials,
+		MaxAge:              options.MaxAge,
+		AllowOriginFunc:     options.AllowOriginFunc,
+		OptionsPassthrough:  options.OptionsPassthrough,
+		AllowPrivateNetwork: options.AllowPrivateNetwork,
 	}
-	if len(normOptions.AllowedOrigins) == 0 {
-		// Default is all origins
+	if len(normOptions.AllowedOrigins) == 0 && normOptions.AllowOriginFunc == nil {
+		// Default is all origins, unless AllowOriginFunc is set instead
 		normOptions.AllowedOrigins = []string{"*"}
 	}
 	if len(normOptions.AllowedMethods) == 0 {
 		// Default is simple methods
 		normOptions.AllowedMethods = []string{"GET", "POST"}
 	}
+	allowedOriginsAll := false
+	var wildcardOrigins []wildcard
+	originSet := make(map[string]struct{}, len(normOptions.AllowedOrigins))
+	for _, origin := range normOptions.AllowedOrigins {
+		switch {
+		case origin == "*":
+			allowedOriginsAll = true
+		case strings.ContainsRune(origin, '*'):
+			if strings.Count(origin, "*") > 1 {
+				panic(fmt.Sprintf("cors: invalid origin pattern %q: only a single \"*\" wildcard is supported", origin))
+			}
+			wildcardOrigins = append(wildcardOrigins, newWildcard(origin))
+		default:
+			originSet[origin] = struct{}{}
+		}
+	}
+
+	methodSet := make(map[string]struct{}, len(normOptions.AllowedMethods))
+	for _, m := range normOptions.AllowedMethods {
+		methodSet[m] = struct{}{}
+	}
+
+	allowedHeadersAll := false
+	headerSet := make(map[string]struct{}, len(normOptions.AllowedHeaders))
+	for _, h := range normOptions.AllowedHeaders {
+		if h == "*" {
+			allowedHeadersAll = true
+			continue
+		}
+		headerSet[h] = struct{}{}
+	}
+	allowedHeadersStr := "*"
+	if !allowedHeadersAll {
+		allowedHeadersStr = strings.Join(normOptions.AllowedHeaders, ", ")
+	}
+
+	var exposedHeadersStr string
+	if len(normOptions.ExposedHeaders) > 0 {
+		exposedHeadersStr = strings.Join(normOptions.ExposedHeaders, ", ")
+	}
+
+	var maxAgeStr string
+	if intVal(normOptions.MaxAge) > 0 {
+		maxAgeStr = strconv.Itoa(intVal(normOptions.MaxAge))
+	}
+
+	timingAllowOriginsAll := false
+	timingAllowOriginSet := make(map[string]struct{}, len(options.TimingAllowOrigins))
+	for _, origin := range convert(options.TimingAllowOrigins, strings.ToLower) {
+		if origin == "*" {
+			timingAllowOriginsAll = true
+			continue
+		}
+		timingAllowOriginSet[origin] = struct{}{}
+	}
+
+	optionsSuccessStatus := options.OptionsSuccessStatus
+	if optionsSuccessStatus == 0 {
+		optionsSuccessStatus = http.StatusNoContent
+	}
+	var logger Logger
+	if boolVal(options.Debug) {
+		logger = options.Logger
+		if logger == nil {
+			logger = log.New(os.Stdout, "[cors] ", log.LstdFlags)
+		}
+	}
 	return &Cors{
-		options: normOptions,
+		options:               normOptions,
+		base:                  options,
+		originSet:             originSet,
+		allowedOriginsAll:     allowedOriginsAll,
+		wildcardOrigins:       wildcardOrigins,
+		methodSet:             methodSet,
+		headerSet:             headerSet,
+		allowedHeadersAll:     allowedHeadersAll,
+		allowMethodsStr:       strings.Join(normOptions.AllowedMethods, ", "),
+		allowedHeadersStr:     allowedHeadersStr,
+		exposedHeadersStr:     exposedHeadersStr,
+		maxAgeStr:             maxAgeStr,
+		optionsSuccessStatus:  optionsSuccessStatus,
+		logger:                logger,
+		timingAllowOriginSet:  timingAllowOriginSet,
+		timingAllowOriginsAll: timingAllowOriginsAll,
 	}
 }
 
@@ -87,23 +252,129 @@ func Default() *Cors {
 	return New(Options{})
 }
 
+// AllowAll creates a new Cors handler that allows all origins and headers
+// and the standard set of methods, with credentials disabled (a wildcard
+// origin combined with credentials is forbidden by the spec). This is the
+// common case for S3-compatible gateways and internal admin APIs that have
+// no per-origin access boundary to enforce.
+func AllowAll() *Cors {
+	return New(Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"},
+		AllowedHeaders: []string{"*"},
+	})
+}
+
+// logf writes a debug trace if Options.Debug was set.
+func (cors *Cors) logf(format string, v ...interface{}) {
+	if cors.logger != nil {
+		cors.logger.Printf(format, v...)
+	}
+}
+
+// With returns a new *Cors whose options are opts merged on top of cors's
+// own base options via MergeOptions. cors itself is left untouched.
+func (cors *Cors) With(opts Options) *Cors {
+	return New(MergeOptions(cors.base, opts))
+}
+
+// HandleFunc returns pattern together with an http.HandlerFunc that applies
+// opts (merged on top of cors's base policy, see With) to requests matching
+// it. It's meant to be registered directly with a router whose Handle/Get/etc
+// methods take a (pattern, handler) pair, e.g. with go-chi, where c is a
+// *cors.Cors:
+//
+//	r.Handle(c.HandleFunc("/public", cors.Options{AllowedOrigins: []string{"*"}}))
+func (cors *Cors) HandleFunc(pattern string, opts Options) (string, http.HandlerFunc) {
+	route := cors.With(opts)
+	return pattern, route.HandlerFunc
+}
+
+// MergeOptions merges override onto base and returns the result: any field
+// set on override (a non-empty slice, a non-nil func/pointer) replaces the
+// corresponding field of base, otherwise base's value passes through
+// unchanged. AllowCredentials, MaxAge, OptionsPassthrough, Debug and
+// AllowPrivateNetwork are pointers precisely so a route can override them to
+// the zero value (e.g. disable credentials a base policy enabled) rather
+// than only ever turning them on.
+func MergeOptions(base, override Options) Options {
+	merged := base
+	if len(override.AllowedOrigins) > 0 {
+		merged.AllowedOrigins = override.AllowedOrigins
+	}
+	if override.AllowOriginFunc != nil {
+		merged.AllowOriginFunc = override.AllowOriginFunc
+	}
+	if len(override.AllowedMethods) > 0 {
+		merged.AllowedMethods = override.AllowedMethods
+	}
+	if len(override.AllowedHeaders) > 0 {
+		merged.AllowedHeaders = override.AllowedHeaders
+	}
+	if len(override.ExposedHeaders) > 0 {
+		merged.ExposedHeaders = override.ExposedHeaders
+	}
+	if override.AllowCredentials != nil {
+		merged.AllowCredentials = override.AllowCredentials
+	}
+	if override.MaxAge != nil {
+		merged.MaxAge = override.MaxAge
+	}
+	if override.OptionsPassthrough != nil {
+		merged.OptionsPassthrough = override.OptionsPassthrough
+	}
+	if override.OptionsSuccessStatus > 0 {
+		merged.OptionsSuccessStatus = override.OptionsSuccessStatus
+	}
+	if override.Debug != nil {
+		merged.Debug = override.Debug
+	}
+	if override.Logger != nil {
+		merged.Logger = override.Logger
+	}
+	if override.AllowPrivateNetwork != nil {
+		merged.AllowPrivateNetwork = override.AllowPrivateNetwork
+	}
+	if len(override.TimingAllowOrigins) > 0 {
+		merged.TimingAllowOrigins = override.TimingAllowOrigins
+	}
+	return merged
+}
+
+// isPreflight reports whether r is a CORS preflight request. A plain OPTIONS
+// request without Access-Control-Request-Method is not a preflight and
+// should reach the downstream handler untouched.
+func isPreflight(r *http.Request) bool {
+	return r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
 // Handler apply the CORS specification on the request, and add relevant CORS headers
 // as necessary.
 func (cors *Cors) Handler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == "OPTIONS" {
+		if isPreflight(r) {
 			cors.handlePreflight(w, r)
-		} else {
-			cors.handleActualRequest(w, r)
-			h.ServeHTTP(w, r)
+			if boolVal(cors.options.OptionsPassthrough) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Content-Length", "0")
+			w.WriteHeader(cors.optionsSuccessStatus)
+			return
 		}
+		cors.handleActualRequest(w, r)
+		h.ServeHTTP(w, r)
 	})
 }
 
 // Martini compatible handler
 func (cors *Cors) HandlerFunc(w http.ResponseWriter, r *http.Request) {
-	if r.Method == "OPTIONS" {
+	if isPreflight(r) {
 		cors.handlePreflight(w, r)
+		if !boolVal(cors.options.OptionsPassthrough) {
+			w.Header().Set("Content-Length", "0")
+			w.WriteHeader(cors.optionsSuccessStatus)
+		}
 	} else {
 		cors.handleActualRequest(w, r)
 	}
@@ -111,45 +382,81 @@ func (cors *Cors) HandlerFunc(w http.ResponseWriter, r *http.Request) {
 
 // Negroni compatible interface
 func (cors *Cors) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	if r.Method == "OPTIONS" {
+	if isPreflight(r) {
 		cors.handlePreflight(w, r)
-	} else {
-		cors.handleActualRequest(w, r)
-		next(w, r)
+		if boolVal(cors.options.OptionsPassthrough) {
+			next(w, r)
+			return
+		}
+		w.Header().Set("Content-Length", "0")
+		w.WriteHeader(cors.optionsSuccessStatus)
+		return
 	}
+	cors.handleActualRequest(w, r)
+	next(w, r)
 }
 
-// handlePreflight handles pre-flight CORS requests
+// handlePreflight handles pre-flight CORS requests by setting the relevant
+// response headers. It never writes a status code or terminates the
+// response; callers are responsible for doing so once it returns, since the
+// exact mechanism for short-circuiting differs across the handler flavors
+// above.
 func (cors *Cors) handlePreflight(w http.ResponseWriter, r *http.Request) {
 	options := cors.options
 	headers := w.Header()
+	// The response to a preflight request always depends on the Origin and the
+	// requested method/headers, so intermediate caches must not serve it across
+	// different values of those headers.
+	headers.Add("Vary", "Origin")
+	headers.Add("Vary", "Access-Control-Request-Method")
+	headers.Add("Vary", "Access-Control-Request-Headers")
+	headers.Add("Vary", "Access-Control-Request-Private-Network")
 	origin := r.Header.Get("Origin")
-	if r.Method != "OPTIONS" || origin == "" || !cors.isOriginAllowed(origin) {
+	if origin == "" {
+		cors.logf("Preflight: no Origin header")
+		return
+	}
+	lowerOrigin := strings.ToLower(origin)
+	if !cors.isOriginAllowed(origin, lowerOrigin) {
+		cors.logf("Preflight: origin %q not allowed", origin)
 		return
 	}
-	reqMethod := r.Header.Get("Access-Control-Request-Method")
+	reqMethod := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
 	if !cors.isMethodAllowed(reqMethod) {
+		cors.logf("Preflight: method %q not allowed", reqMethod)
 		return
 	}
-	reqHeaders := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+	privateNetwork := r.Header.Get("Access-Control-Request-Private-Network") == "true"
+	if privateNetwork && !boolVal(options.AllowPrivateNetwork) {
+		cors.logf("Preflight: private network access requested but not allowed")
+		return
+	}
+	bufp := reqHeaderListPool.Get().(*[]string)
+	defer reqHeaderListPool.Put(bufp)
+	reqHeaders := parseHeaderList(*bufp, r.Header.Get("Access-Control-Request-Headers"))
+	*bufp = reqHeaders
 	if !cors.areHeadersAllowed(reqHeaders) {
+		cors.logf("Preflight: headers %v not allowed", reqHeaders)
 		return
 	}
 	headers.Set("Access-Control-Allow-Origin", origin)
-	// Spec says: Since the list of methods can be unbounded, simply returning the method indicated
-	// by Access-Control-Request-Method (if supported) can be enough
-	headers.Set("Access-Control-Allow-Methods", strings.ToUpper(reqMethod))
+	// Spec says: since the list of methods can be unbounded, simply returning
+	// the full allowed method list (precomputed once in New) is enough, and
+	// cheaper than echoing back and re-validating the requested method.
+	headers.Set("Access-Control-Allow-Methods", cors.allowMethodsStr)
 	if len(reqHeaders) > 0 {
-
-		// Spec says: Since the list of headers can be unbounded, simply returning supported headers
-		// from Access-Control-Request-Headers can be enough
-		headers.Set("Access-Control-Allow-Headers", strings.Join(reqHeaders, ", "))
+		// Spec says: since the list of headers can be unbounded, simply
+		// returning the full allowed header list is enough.
+		headers.Set("Access-Control-Allow-Headers", cors.allowedHeadersStr)
 	}
-	if options.AllowCredentials {
+	if boolVal(options.AllowCredentials) {
 		headers.Set("Access-Control-Allow-Credentials", "true")
 	}
-	if options.MaxAge > 0 {
-		headers.Set("Access-Control-Max-Age", strconv.Itoa(options.MaxAge))
+	if cors.maxAgeStr != "" {
+		headers.Set("Access-Control-Max-Age", cors.maxAgeStr)
+	}
+	if privateNetwork {
+		headers.Set("Access-Control-Allow-Private-Network", "true")
 	}
 }
 
@@ -157,78 +464,96 @@ func (cors *Cors) handlePreflight(w http.ResponseWriter, r *http.Request) {
 func (cors *Cors) handleActualRequest(w http.ResponseWriter, r *http.Request) {
 	options := cors.options
 	headers := w.Header()
+	// The allowed origin (and therefore this response) varies by request.
+	headers.Add("Vary", "Origin")
 	origin := r.Header.Get("Origin")
-	if r.Method == "OPTIONS" || origin == "" || !cors.isOriginAllowed(origin) {
+	var lowerOrigin string
+	if origin != "" {
+		lowerOrigin = strings.ToLower(origin)
+	}
+	// Timing-Allow-Origin grants Resource Timing visibility only; it's
+	// independent of whether the request is itself CORS-allowed below.
+	if origin != "" && cors.isTimingAllowedOrigin(lowerOrigin) {
+		headers.Set("Timing-Allow-Origin", origin)
+	}
+	if origin == "" || !cors.isOriginAllowed(origin, lowerOrigin) {
+		return
+	}
+	method := strings.ToUpper(r.Method)
+	if method == "OPTIONS" {
 		return
 	}
 	// Note that spec does define a way to specifically disallow a simple method like GET or
 	// POST. Access-Control-Allow-Methods is only used for pre-flight requests and the
 	// spec doesn't instruct to check the allowed methods for simple cross-origin requests.
 	// We think it's a nice feature to be able to have control on those methods though.
-	if !cors.isMethodAllowed(r.Method) {
+	if !cors.isMethodAllowed(method) {
 		return
 	}
 	headers.Set("Access-Control-Allow-Origin", origin)
-	if len(options.ExposedHeaders) > 0 {
-		headers.Set("Access-Control-Expose-Headers", strings.Join(options.ExposedHeaders, ", "))
+	if cors.exposedHeadersStr != "" {
+		headers.Set("Access-Control-Expose-Headers", cors.exposedHeadersStr)
 	}
-	if options.AllowCredentials {
+	if boolVal(options.AllowCredentials) {
 		headers.Set("Access-Control-Allow-Credentials", "true")
 	}
 }
 
-// isOriginAllowed checks if a given origin is allowed to perform cross-domain requests
-// on the endpoint
-func (cors *Cors) isOriginAllowed(origin string) bool {
-	allowedOrigins := cors.options.AllowedOrigins
-	origin = strings.ToLower(origin)
-	for _, allowedOrigin := range allowedOrigins {
-		switch allowedOrigin {
-		case "*":
-			return true
-		case origin:
+// isOriginAllowed checks if a given origin is allowed to perform cross-domain
+// requests on the endpoint. lowerOrigin must be strings.ToLower(origin).
+func (cors *Cors) isOriginAllowed(origin, lowerOrigin string) bool {
+	if cors.allowedOriginsAll {
+		return true
+	}
+	if _, ok := cors.originSet[lowerOrigin]; ok {
+		return true
+	}
+	for _, w := range cors.wildcardOrigins {
+		if w.match(lowerOrigin) {
 			return true
 		}
 	}
+	if cors.options.AllowOriginFunc != nil {
+		// Called with the raw Origin header, not lowerOrigin
+		return cors.options.AllowOriginFunc(origin)
+	}
 	return false
 }
 
-// isMethodAllowed checks if a given method can be used as part of a cross-domain request
-// on the endpoing
+// isTimingAllowedOrigin checks if a given origin should receive Resource
+// Timing detail via the Timing-Allow-Origin header. lowerOrigin must be
+// strings.ToLower(origin).
+func (cors *Cors) isTimingAllowedOrigin(lowerOrigin string) bool {
+	if cors.timingAllowOriginsAll {
+		return true
+	}
+	_, ok := cors.timingAllowOriginSet[lowerOrigin]
+	return ok
+}
+
+// isMethodAllowed checks if a given method can be used as part of a
+// cross-domain request on the endpoint. method must already be uppercased.
 func (cors *Cors) isMethodAllowed(method string) bool {
-	allowedMethods := cors.options.AllowedMethods
-	if len(allowedMethods) == 0 {
+	if len(cors.methodSet) == 0 {
 		// If no method allowed, always return false, even for preflight request
 		return false
 	}
-	method = strings.ToUpper(method)
 	if method == "OPTIONS" {
 		// Always allow preflight requests
 		return true
 	}
-	for _, allowedMethod := range allowedMethods {
-		if allowedMethod == method {
-			return true
-		}
-	}
-	return false
+	_, ok := cors.methodSet[method]
+	return ok
 }
 
 // areHeadersAllowed checks if a given list of headers are allowed to used within
 // a cross-domain request.
 func (cors *Cors) areHeadersAllowed(requestedHeaders []string) bool {
-	if len(requestedHeaders) == 0 {
+	if cors.allowedHeadersAll || len(requestedHeaders) == 0 {
 		return true
 	}
 	for _, header := range requestedHeaders {
-		found := false
-		for _, allowedHeader := range cors.options.AllowedHeaders {
-			if header == allowedHeader {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if _, ok := cors.headerSet[header]; !ok {
 			return false
 		}
 	}
@@ -0,0 +1,97 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func preflightRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	r.Header.Set("Origin", "https://foo.com")
+	r.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	return r
+}
+
+func TestHandlerTerminatesPreflightByDefault(t *testing.T) {
+	c := New(Options{AllowedOrigins: []string{"https://foo.com"}})
+	downstreamCalled := false
+	h := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamCalled = true
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, preflightRequest())
+
+	if downstreamCalled {
+		t.Error("downstream handler ran for a preflight request, want it short-circuited")
+	}
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestHandlerOptionsPassthrough(t *testing.T) {
+	c := New(Options{
+		AllowedOrigins:     []string{"https://foo.com"},
+		OptionsPassthrough: Bool(true),
+	})
+	downstreamCalled := false
+	h := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamCalled = true
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, preflightRequest())
+
+	if !downstreamCalled {
+		t.Error("downstream handler did not run with OptionsPassthrough set")
+	}
+}
+
+func TestHandlerOptionsSuccessStatus(t *testing.T) {
+	c := New(Options{
+		AllowedOrigins:       []string{"https://foo.com"},
+		OptionsSuccessStatus: http.StatusOK,
+	})
+	h := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, preflightRequest())
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestHandlerFuncTerminatesPreflightByDefault(t *testing.T) {
+	c := New(Options{AllowedOrigins: []string{"https://foo.com"}})
+	w := httptest.NewRecorder()
+	c.HandlerFunc(w, preflightRequest())
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestServeHTTPOptionsPassthrough(t *testing.T) {
+	c := New(Options{
+		AllowedOrigins:     []string{"https://foo.com"},
+		OptionsPassthrough: Bool(true),
+	})
+	nextCalled := false
+	next := func(w http.ResponseWriter, r *http.Request) { nextCalled = true }
+
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, preflightRequest(), next)
+
+	if !nextCalled {
+		t.Error("next handler did not run with OptionsPassthrough set")
+	}
+}
+
+func TestIsPreflightIgnoresPlainOptions(t *testing.T) {
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	if isPreflight(r) {
+		t.Error("a plain OPTIONS request without Access-Control-Request-Method was treated as a preflight")
+	}
+}
@@ -0,0 +1,65 @@
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsOriginAllowed(t *testing.T) {
+	c := New(Options{
+		AllowedOrigins: []string{"https://foo.com", "https://*.example.com"},
+	})
+
+	cases := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://foo.com", true},
+		{"https://FOO.com", true}, // case-insensitive
+		{"https://bar.example.com", true},
+		{"https://example.com", false}, // wildcard requires a subdomain
+		{"https://bar.com", false},
+	}
+	for _, tt := range cases {
+		lower := strings.ToLower(tt.origin)
+		if got := c.isOriginAllowed(tt.origin, lower); got != tt.want {
+			t.Errorf("isOriginAllowed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestNewPanicsOnMultiWildcardOrigin(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("New did not panic on an origin with more than one \"*\"")
+		}
+	}()
+	New(Options{AllowedOrigins: []string{"https://*.*.example.com"}})
+}
+
+func TestAllowOriginFuncReceivesRawOrigin(t *testing.T) {
+	var got string
+	c := New(Options{
+		AllowOriginFunc: func(origin string) bool {
+			got = origin
+			return true
+		},
+	})
+
+	const raw = "https://Foo.EXAMPLE.com"
+	if !c.isOriginAllowed(raw, strings.ToLower(raw)) {
+		t.Fatal("expected origin to be allowed")
+	}
+	if got != raw {
+		t.Errorf("AllowOriginFunc got %q, want raw origin %q", got, raw)
+	}
+}
+
+func TestAllowOriginFuncNotShadowedByDefaultWildcard(t *testing.T) {
+	c := New(Options{
+		AllowOriginFunc: func(origin string) bool { return false },
+	})
+	if c.isOriginAllowed("https://anything.com", "https://anything.com") {
+		t.Fatal("AllowOriginFunc result was overridden by the default \"*\" AllowedOrigins")
+	}
+}
@@ -0,0 +1,102 @@
+package cors
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Bool returns a pointer to b, for Options fields like AllowCredentials.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Int returns a pointer to i, for Options fields like MaxAge.
+func Int(i int) *int {
+	return &i
+}
+
+// boolVal reports the value pointed to by b, or false if b is nil.
+func boolVal(b *bool) bool {
+	return b != nil && *b
+}
+
+// intVal reports the value pointed to by i, or 0 if i is nil.
+func intVal(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// convert applies the conversion function c to each element of s and
+// returns the resulting slice.
+func convert(s []string, c func(string) string) []string {
+	out := make([]string, len(s))
+	for i, v := range s {
+		out[i] = c(v)
+	}
+	return out
+}
+
+// toHeader canonicalizes a header name, e.g. "x-requested-with" becomes
+// "X-Requested-With".
+func toHeader(s string) string {
+	return http.CanonicalHeaderKey(s)
+}
+
+// reqHeaderListPool reuses the backing slices parseHeaderList fills in, since
+// every preflight request would otherwise allocate a fresh one.
+var reqHeaderListPool = sync.Pool{
+	New: func() interface{} {
+		s := make([]string, 0, 8)
+		return &s
+	},
+}
+
+// parseHeaderList parses and canonicalizes a comma-separated list of headers
+// as found in the Access-Control-Request-Headers header into buf's storage;
+// whatever buf held before is discarded.
+// Callers on a hot path should get buf from reqHeaderListPool and return it
+// with reqHeaderListPool.Put once done with the result.
+func parseHeaderList(buf []string, headerList string) []string {
+	buf = buf[:0]
+	for headerList != "" {
+		var h string
+		if i := strings.IndexByte(headerList, ','); i >= 0 {
+			h, headerList = headerList[:i], headerList[i+1:]
+		} else {
+			h, headerList = headerList, ""
+		}
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		buf = append(buf, toHeader(h))
+	}
+	return buf
+}
+
+// wildcard matches strings that start and end with a fixed prefix/suffix,
+// with a single "*" standing in for anything in between, e.g.
+// "https://*.example.com" compiled from the pattern the user supplied in
+// Options.AllowedOrigins.
+type wildcard struct {
+	prefix string
+	suffix string
+}
+
+func (w wildcard) match(s string) bool {
+	return len(s) >= len(w.prefix)+len(w.suffix) &&
+		strings.HasPrefix(s, w.prefix) &&
+		strings.HasSuffix(s, w.suffix)
+}
+
+// newWildcard compiles pattern into a wildcard matcher. pattern must contain
+// exactly one "*"; callers are responsible for validating that beforehand
+// (see New, which rejects patterns with more than one "*" outright rather
+// than let them silently compile into an unmatchable suffix).
+func newWildcard(pattern string) wildcard {
+	i := strings.IndexByte(pattern, '*')
+	return wildcard{prefix: pattern[:i], suffix: pattern[i+1:]}
+}